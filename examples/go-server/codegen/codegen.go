@@ -0,0 +1,358 @@
+// Package codegen turns the analyzer's collected endpoints into a runnable
+// client SDK plus a Postman v2.1 collection and an HTTPie .http file. It
+// takes openapi.Endpoint rather than the analyzer's own types directly,
+// since the analyzer lives in package main and main packages cannot be
+// imported; reusing openapi's types avoids a second copy of the same shape.
+// Adding a new target language is a matter of implementing one more
+// template function and registering it in Generate.
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"documatic/examples/go-server/openapi"
+)
+
+// Generate produces the generated SDK files for lang ("go", "ts", or
+// "python"), keyed by filename, along with a Postman collection and an
+// HTTPie .http file shared by every language.
+func Generate(lang, title string, endpoints []openapi.Endpoint) (map[string]string, error) {
+	files := map[string]string{
+		"collection.postman.json": postmanCollection(title, endpoints),
+		"requests.http":           httpieFile(endpoints),
+	}
+
+	switch lang {
+	case "go":
+		files["service.go"] = goService(endpoints)
+		files["transport_http.go"] = goTransport()
+		files["client.go"] = goClient(endpoints)
+	case "ts":
+		files["client.ts"] = tsClient(endpoints)
+	case "python":
+		files["client.py"] = pythonClient(endpoints)
+	default:
+		return nil, fmt.Errorf("codegen: unsupported lang %q: want go, ts, or python", lang)
+	}
+
+	return files, nil
+}
+
+// goService renders a Service interface with one method per endpoint, the
+// "onion" entry point the rest of the Go output is built around.
+func goService(endpoints []openapi.Endpoint) string {
+	names := operationNames(endpoints)
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by documatic codegen. DO NOT EDIT.\n\n")
+	sb.WriteString("package client\n\n")
+	sb.WriteString("import \"context\"\n\n")
+	sb.WriteString("// Service is implemented by the generated client, with one method per\n")
+	sb.WriteString("// endpoint the analyzer discovered.\n")
+	sb.WriteString("type Service interface {\n")
+	for i, ep := range endpoints {
+		if hasRequestBody(ep) {
+			fmt.Fprintf(&sb, "\t%s(ctx context.Context, body interface{}) ([]byte, error)\n", names[i])
+		} else {
+			fmt.Fprintf(&sb, "\t%s(ctx context.Context) ([]byte, error)\n", names[i])
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// goTransport renders the encode/decode helpers shared by every generated
+// Client method; it doesn't depend on the endpoint list since encoding and
+// decoding are uniform across operations.
+func goTransport() string {
+	var sb strings.Builder
+	sb.WriteString("// Code generated by documatic codegen. DO NOT EDIT.\n\n")
+	sb.WriteString("package client\n\n")
+	sb.WriteString("import (\n\t\"bytes\"\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n)\n\n")
+	sb.WriteString("// encodeRequest marshals body as JSON, or returns a nil io.Reader when body is nil.\n")
+	sb.WriteString("func encodeRequest(body interface{}) (io.Reader, error) {\n")
+	sb.WriteString("\tif body == nil {\n\t\treturn nil, nil\n\t}\n")
+	sb.WriteString("\traw, err := json.Marshal(body)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	sb.WriteString("\treturn bytes.NewReader(raw), nil\n}\n\n")
+	sb.WriteString("// decodeResponse reads the full response body, surfacing non-2xx statuses as an error.\n")
+	sb.WriteString("func decodeResponse(resp *http.Response) ([]byte, error) {\n")
+	sb.WriteString("\tdefer resp.Body.Close()\n")
+	sb.WriteString("\tbody, err := io.ReadAll(resp.Body)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	sb.WriteString("\tif resp.StatusCode >= 300 {\n\t\treturn nil, &httpError{StatusCode: resp.StatusCode, Body: string(body)}\n\t}\n")
+	sb.WriteString("\treturn body, nil\n}\n\n")
+	sb.WriteString("// httpError is returned when the server responds with a non-2xx status.\n")
+	sb.WriteString("type httpError struct {\n\tStatusCode int\n\tBody       string\n}\n\n")
+	sb.WriteString("func (e *httpError) Error() string {\n")
+	sb.WriteString("\treturn fmt.Sprintf(\"documatic client: unexpected status %d: %s\", e.StatusCode, e.Body)\n")
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// goClient renders the Client struct and its Service implementation, one
+// method per endpoint, wrapping net/http directly.
+func goClient(endpoints []openapi.Endpoint) string {
+	names := operationNames(endpoints)
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by documatic codegen. DO NOT EDIT.\n\n")
+	sb.WriteString("package client\n\n")
+	sb.WriteString("import (\n\t\"context\"\n\t\"net/http\"\n)\n\n")
+	sb.WriteString("// Client is a generated net/http-backed implementation of Service.\n")
+	sb.WriteString("type Client struct {\n\tBaseURL    string\n\tHTTPClient *http.Client\n}\n\n")
+	sb.WriteString("// NewClient returns a Client using http.DefaultClient.\n")
+	sb.WriteString("func NewClient(baseURL string) *Client {\n\treturn &Client{BaseURL: baseURL, HTTPClient: http.DefaultClient}\n}\n\n")
+
+	for i, ep := range endpoints {
+		name := names[i]
+		method := strings.ToUpper(ep.Method)
+		withBody := hasRequestBody(ep)
+
+		fmt.Fprintf(&sb, "// %s calls %s %s.\n", name, method, ep.Path)
+		if withBody {
+			fmt.Fprintf(&sb, "func (c *Client) %s(ctx context.Context, body interface{}) ([]byte, error) {\n", name)
+		} else {
+			fmt.Fprintf(&sb, "func (c *Client) %s(ctx context.Context) ([]byte, error) {\n", name)
+		}
+
+		if withBody {
+			sb.WriteString("\treader, err := encodeRequest(body)\n")
+		} else {
+			sb.WriteString("\treader, err := encodeRequest(nil)\n")
+		}
+		sb.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n\n")
+		fmt.Fprintf(&sb, "\treq, err := http.NewRequestWithContext(ctx, %q, c.BaseURL+%q, reader)\n", method, ep.Path)
+		sb.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+		sb.WriteString("\tif reader != nil {\n\t\treq.Header.Set(\"Content-Type\", \"application/json\")\n\t}\n\n")
+		sb.WriteString("\tresp, err := c.HTTPClient.Do(req)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+		sb.WriteString("\treturn decodeResponse(resp)\n}\n\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// tsClient renders a minimal fetch-based TypeScript client with one method
+// per endpoint.
+func tsClient(endpoints []openapi.Endpoint) string {
+	names := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		names[i] = lowerFirst(operationName(ep))
+	}
+	names = dedupeNames(names)
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by documatic codegen. DO NOT EDIT.\n\n")
+	sb.WriteString("export class Client {\n")
+	sb.WriteString("  constructor(private baseUrl: string) {}\n\n")
+
+	for i, ep := range endpoints {
+		name := names[i]
+		method := strings.ToUpper(ep.Method)
+		withBody := hasRequestBody(ep)
+
+		if withBody {
+			fmt.Fprintf(&sb, "  async %s(body: unknown): Promise<unknown> {\n", name)
+		} else {
+			fmt.Fprintf(&sb, "  async %s(): Promise<unknown> {\n", name)
+		}
+		fmt.Fprintf(&sb, "    const res = await fetch(this.baseUrl + %q, {\n", ep.Path)
+		fmt.Fprintf(&sb, "      method: %q,\n", method)
+		if withBody {
+			sb.WriteString("      headers: { \"Content-Type\": \"application/json\" },\n")
+			sb.WriteString("      body: JSON.stringify(body),\n")
+		}
+		sb.WriteString("    })\n")
+		sb.WriteString("    if (!res.ok) throw new Error(`documatic client: unexpected status ${res.status}`)\n")
+		sb.WriteString("    return res.json()\n")
+		sb.WriteString("  }\n\n")
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// pythonClient renders a minimal requests-based Python client with one
+// method per endpoint.
+func pythonClient(endpoints []openapi.Endpoint) string {
+	names := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		names[i] = pythonMethodName(ep)
+	}
+	names = dedupeNames(names)
+
+	var sb strings.Builder
+	sb.WriteString("# Code generated by documatic codegen. DO NOT EDIT.\n\n")
+	sb.WriteString("import requests\n\n\n")
+	sb.WriteString("class Client:\n")
+	sb.WriteString("    def __init__(self, base_url):\n        self.base_url = base_url\n\n")
+
+	for i, ep := range endpoints {
+		name := names[i]
+		method := strings.ToLower(ep.Method)
+		withBody := hasRequestBody(ep)
+
+		if withBody {
+			fmt.Fprintf(&sb, "    def %s(self, body):\n", name)
+			fmt.Fprintf(&sb, "        resp = requests.%s(self.base_url + %q, json=body)\n", method, ep.Path)
+		} else {
+			fmt.Fprintf(&sb, "    def %s(self):\n", name)
+			fmt.Fprintf(&sb, "        resp = requests.%s(self.base_url + %q)\n", method, ep.Path)
+		}
+		sb.WriteString("        resp.raise_for_status()\n")
+		sb.WriteString("        return resp.json()\n\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+type postmanURL struct {
+	Raw string `json:"raw"`
+}
+
+type postmanRequest struct {
+	Method string     `json:"method"`
+	URL    postmanURL `json:"url"`
+}
+
+type postmanItem struct {
+	Name    string         `json:"name"`
+	Request postmanRequest `json:"request"`
+}
+
+type postmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+type postmanDoc struct {
+	Info postmanInfo   `json:"info"`
+	Item []postmanItem `json:"item"`
+}
+
+// postmanCollection renders a Postman v2.1 collection, one request item per
+// endpoint, referencing a {{baseUrl}} collection variable.
+func postmanCollection(title string, endpoints []openapi.Endpoint) string {
+	doc := postmanDoc{
+		Info: postmanInfo{
+			Name:   title,
+			Schema: "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		},
+	}
+	for _, ep := range endpoints {
+		doc.Item = append(doc.Item, postmanItem{
+			Name: operationName(ep),
+			Request: postmanRequest{
+				Method: strings.ToUpper(ep.Method),
+				URL:    postmanURL{Raw: "{{baseUrl}}" + ep.Path},
+			},
+		})
+	}
+
+	raw, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "{}"
+	}
+	return string(raw)
+}
+
+// httpieFile renders one HTTPie request line per endpoint.
+func httpieFile(endpoints []openapi.Endpoint) string {
+	var sb strings.Builder
+	for _, ep := range endpoints {
+		fmt.Fprintf(&sb, "%s {{baseUrl}}%s\n\n", strings.ToUpper(ep.Method), ep.Path)
+	}
+	return sb.String()
+}
+
+// hasRequestBody reports whether ep takes a request body, based on its HTTP
+// method and whether the analyzer captured a DataShape from a request-side
+// call (Decode/Unmarshal/Bind), per shape.IsRequest.
+func hasRequestBody(ep openapi.Endpoint) bool {
+	switch strings.ToUpper(ep.Method) {
+	case "POST", "PUT", "PATCH":
+	default:
+		return false
+	}
+	for _, shape := range ep.DataShapes {
+		if shape.IsRequest {
+			return true
+		}
+	}
+	return false
+}
+
+// operationName turns an endpoint's method and path into an exported Go
+// identifier, e.g. GET /users/{id} becomes GetUsersId.
+func operationName(ep openapi.Endpoint) string {
+	return strings.Title(strings.ToLower(ep.Method)) + sanitizeIdent(ep.Path)
+}
+
+// operationNames returns operationName(ep) for every endpoint, deduped via
+// dedupeNames. goService and goClient must derive their method names from
+// the same call so the Client stays a valid Service implementation.
+func operationNames(endpoints []openapi.Endpoint) []string {
+	names := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		names[i] = operationName(ep)
+	}
+	return dedupeNames(names)
+}
+
+// dedupeNames suffixes repeated names with 2, 3, ... in order of
+// appearance, so two endpoints that normalize to the same generated
+// identifier (e.g. the same route registered via two different frameworks
+// in the analyzed tree) don't collide in the generated client.
+func dedupeNames(names []string) []string {
+	out := make([]string, len(names))
+	seen := make(map[string]int, len(names))
+	for i, name := range names {
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name = fmt.Sprintf("%s%d", name, n)
+		}
+		out[i] = name
+	}
+	return out
+}
+
+func sanitizeIdent(path string) string {
+	var sb strings.Builder
+	capitalizeNext := true
+	for _, r := range path {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9':
+			if capitalizeNext {
+				sb.WriteRune(unicode.ToUpper(r))
+				capitalizeNext = false
+			} else {
+				sb.WriteRune(r)
+			}
+		default:
+			capitalizeNext = true
+		}
+	}
+	return sb.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func pythonMethodName(ep openapi.Endpoint) string {
+	name := strings.ToLower(ep.Method) + "_" + strings.Trim(ep.Path, "/")
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,104 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"documatic/examples/go-server/openapi"
+)
+
+func TestHasRequestBodyUsesIsRequestFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		ep   openapi.Endpoint
+		want bool
+	}{
+		{
+			name: "request shape named without 'request'",
+			ep: openapi.Endpoint{
+				Method:     "POST",
+				DataShapes: []openapi.Shape{{Name: "SignupBody", IsRequest: true}},
+			},
+			want: true,
+		},
+		{
+			name: "response shape named with 'request'",
+			ep: openapi.Endpoint{
+				Method:     "POST",
+				DataShapes: []openapi.Shape{{Name: "OrderRequest", IsRequest: false}},
+			},
+			want: false,
+		},
+		{
+			name: "GET never takes a body regardless of shapes",
+			ep: openapi.Endpoint{
+				Method:     "GET",
+				DataShapes: []openapi.Shape{{Name: "SignupBody", IsRequest: true}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasRequestBody(tt.ep); got != tt.want {
+				t.Errorf("hasRequestBody(%+v) = %v, want %v", tt.ep, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperationNamesDedupesCollisions(t *testing.T) {
+	endpoints := []openapi.Endpoint{
+		{Method: "GET", Path: "/users"},
+		{Method: "GET", Path: "/users"},
+		{Method: "POST", Path: "/users"},
+	}
+
+	names := operationNames(endpoints)
+	want := []string{"GetUsers", "GetUsers2", "PostUsers"}
+	if len(names) != len(want) {
+		t.Fatalf("got %d names, want %d: %v", len(names), len(want), names)
+	}
+	for i, name := range names {
+		if name != want[i] {
+			t.Errorf("names[%d] = %q, want %q", i, name, want[i])
+		}
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			t.Errorf("duplicate generated name %q", name)
+		}
+		seen[name] = true
+	}
+}
+
+// TestTSAndPythonClientsDedupeCollisions reproduces the server/ dir's shape
+// (the same method+path registered via more than one framework, per
+// d32b85c) and checks tsClient/pythonClient suffix the second method rather
+// than emitting a duplicate method (a TS2393 compile error) or silently
+// shadowing the first (Python).
+func TestTSAndPythonClientsDedupeCollisions(t *testing.T) {
+	endpoints := []openapi.Endpoint{
+		{Method: "GET", Path: "/users"},
+		{Method: "GET", Path: "/users"},
+	}
+
+	ts := tsClient(endpoints)
+	if n := strings.Count(ts, "async getUsers("); n != 1 {
+		t.Errorf("tsClient: got %d occurrences of async getUsers(, want 1", n)
+	}
+	if n := strings.Count(ts, "async getUsers2("); n != 1 {
+		t.Errorf("tsClient: got %d occurrences of async getUsers2(, want 1", n)
+	}
+
+	py := pythonClient(endpoints)
+	if n := strings.Count(py, "def get_users("); n != 1 {
+		t.Errorf("pythonClient: got %d occurrences of def get_users(, want 1", n)
+	}
+	if n := strings.Count(py, "def get_users2("); n != 1 {
+		t.Errorf("pythonClient: got %d occurrences of def get_users2(, want 1", n)
+	}
+}
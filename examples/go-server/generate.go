@@ -0,0 +1,68 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"documatic/examples/go-server/codegen"
+)
+
+// registerGenerateHandler wires POST /generate, which runs the analyzer
+// over dir and returns a zip containing a generated client SDK for lang
+// plus a Postman collection and an HTTPie .http file.
+func registerGenerateHandler() {
+	http.HandleFunc("/generate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		lang := r.URL.Query().Get("lang")
+		if lang == "" {
+			lang = "go"
+		}
+		dir := r.URL.Query().Get("dir")
+		if dir == "" {
+			dir = "."
+		}
+
+		analyzer := NewAnalyzer()
+		if err := analyzer.ParseDirectory(dir); err != nil {
+			http.Error(w, fmt.Sprintf("Error analyzing directory: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		files, err := codegen.Generate(lang, "documatic", toOpenAPIEndpoints(analyzer.GetEndpoints()))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="documatic-client.zip"`)
+		writeZip(w, files)
+	})
+}
+
+// writeZip writes files, sorted by name for a deterministic archive, as a
+// zip stream directly to w.
+func writeZip(w http.ResponseWriter, files map[string]string) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	archive := zip.NewWriter(w)
+	defer archive.Close()
+
+	for _, name := range names {
+		entry, err := archive.Create(name)
+		if err != nil {
+			return
+		}
+		entry.Write([]byte(files[name]))
+	}
+}
@@ -4,12 +4,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/ast"
-	"go/parser"
+	"go/constant"
 	"go/token"
+	"go/types"
 	"log"
 	"net/http"
-	"os"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+
+	"documatic/examples/go-server/openapi"
+	"golang.org/x/tools/go/packages"
 )
 
 // APIDocumentation represents the structure of API documentation
@@ -18,6 +25,8 @@ type APIDocumentation struct {
 	Method      string       `json:"method"`
 	Description string       `json:"description"`
 	DataShapes  []DataShape  `json:"data_shapes,omitempty"`
+	PathParams  []PathParam  `json:"path_params,omitempty"`
+	Middleware  []string     `json:"middleware,omitempty"`
 	CurlExample string       `json:"curl_example"`
 }
 
@@ -26,51 +35,226 @@ type DataShape struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Shape       string `json:"shape"`
+	// StatusCode is the HTTP status the response is sent under, when the
+	// handler makes it statically resolvable (e.g. c.JSON(201, x)). It is
+	// zero for request shapes and for responses whose status couldn't be
+	// resolved, in which case callers should assume 200.
+	StatusCode int `json:"status_code,omitempty"`
+	// IsRequest reports whether this shape was captured from a request-side
+	// call (Decode/Unmarshal/Bind) rather than a response-side one
+	// (Encode/JSON), set directly from the call site rather than guessed
+	// from the struct's name.
+	IsRequest bool `json:"is_request,omitempty"`
+}
+
+// PathParam represents a named placeholder in a route path, e.g. {id} or {category}
+type PathParam struct {
+	Name string `json:"name"`
 }
 
+// muxPathParamPattern matches Gorilla Mux style path variables such as {id} or {id:[0-9]+}
+var muxPathParamPattern = regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*)(?::[^}]*)?\}`)
+
 // Analyzer analyzes Go source files for API endpoints
 type Analyzer struct {
-	fset    *token.FileSet
+	fset *token.FileSet
+
+	// mu guards enpoints and byFile, since a long-lived Analyzer may be read
+	// by HTTP handlers while a watch mode goroutine re-populates it.
+	mu       sync.Mutex
 	enpoints []APIDocumentation
+	// byFile indexes the same endpoints by the source file that declared
+	// them, so a watcher can tell which endpoints to drop when a file
+	// changes or is removed.
+	byFile map[string][]APIDocumentation
+	// currentFile is the file analyzeFile is currently walking, used to key
+	// byFile as endpoints are discovered.
+	currentFile string
+
+	// muxPrefixes maps a Gorilla Mux subrouter variable name to the path prefix
+	// it was created with, so routes registered on it can be resolved transitively.
+	muxPrefixes map[string]string
+	// handlerFuncs maps a handler function name to its declaration, shared
+	// across every file in the package currently being analyzed, so a
+	// handler can be looked up from its registration site for its doc
+	// comment and body.
+	handlerFuncs map[string]*ast.FuncDecl
+	// bodyComments maps a handler function name to the leading comment on
+	// the first statement of its body, e.g. the "GET /ping - Liveness
+	// probe" line in this repo's fixtures. findHandlerComment falls back to
+	// this when the handler has no true FuncDecl.Doc, since these fixtures
+	// document handlers with a comment just inside the body rather than one
+	// directly above the func keyword.
+	bodyComments map[string]string
+	// typesInfo holds the type information for the package currently being
+	// analyzed, used to resolve the concrete struct type behind a handler's
+	// request/response variables.
+	typesInfo *types.Info
+	// chiPrefixStack tracks the path prefix of each r.Route(...) closure the
+	// current walk is nested inside, so routes registered deep inside nested
+	// Route calls resolve their full path.
+	chiPrefixStack []string
 }
 
 // NewAnalyzer creates a new analyzer instance
 func NewAnalyzer() *Analyzer {
 	return &Analyzer{
-		fset: token.NewFileSet(),
+		fset:        token.NewFileSet(),
+		byFile:      make(map[string][]APIDocumentation),
+		muxPrefixes: make(map[string]string),
 	}
 }
 
-// ParseDirectory parses all .go files in a directory
+// ParseDirectory loads dir and every package beneath it ("./...") with full
+// type information and analyzes every file in them for API endpoints.
 func (a *Analyzer) ParseDirectory(dir string) error {
-	pkgs, err := parser.ParseDir(a.fset, dir, func(info os.FileInfo) bool {
-		return !strings.HasSuffix(info.Name(), "_test.go")
-	}, parser.ParseComments)
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:  dir,
+		Fset: a.fset,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
 	if err != nil {
 		return err
 	}
 
 	for _, pkg := range pkgs {
-		for _, file := range pkg.Files {
+		a.typesInfo = pkg.TypesInfo
+		a.collectHandlerFuncs(pkg.Syntax)
+		for _, file := range pkg.Syntax {
 			a.analyzeFile(file)
 		}
 	}
 	return nil
 }
 
+// collectHandlerFuncs indexes every top-level function declaration in a
+// package by name so route registrations can resolve their handler's doc
+// comment and body regardless of which file declares it. It also records
+// each handler's body-leading comment into bodyComments, for handlers whose
+// description lives just inside the body rather than above the func
+// keyword.
+func (a *Analyzer) collectHandlerFuncs(files []*ast.File) {
+	a.handlerFuncs = make(map[string]*ast.FuncDecl)
+	a.bodyComments = make(map[string]string)
+	for _, file := range files {
+		cmap := ast.NewCommentMap(a.fset, file, file.Comments)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			a.handlerFuncs[fn.Name.Name] = fn
+
+			if fn.Body == nil || len(fn.Body.List) == 0 {
+				continue
+			}
+			if cgs := cmap[fn.Body.List[0]]; len(cgs) > 0 {
+				if text := strings.TrimSpace(cgs[0].Text()); text != "" {
+					a.bodyComments[fn.Name.Name] = text
+				}
+			}
+		}
+	}
+}
+
 // analyzeFile analyzes a single Go file for API endpoints
 func (a *Analyzer) analyzeFile(file *ast.File) {
+	a.currentFile = a.fset.Position(file.Pos()).Filename
+
+	// Reset the per-file subrouter symbol table, then do a first pass to
+	// resolve every `x := r.PathPrefix("/a").Subrouter()` assignment before
+	// the second pass tries to resolve routes registered on x.
+	a.muxPrefixes = make(map[string]string)
+	a.collectMuxPrefixes(file)
+
+	// Chi's nested r.Route(prefix, func(r chi.Router){...}) closures need a
+	// true prefix stack pushed/popped around the closure body, which the
+	// shared CallExpr dispatch below can't express, so it gets its own walk.
+	a.chiPrefixStack = nil
+	a.walkChiBlock(file)
+
 	ast.Inspect(file, func(n ast.Node) bool {
 		switch x := n.(type) {
 		case *ast.CallExpr:
 			a.extractHTTPCalls(x)
 			a.extractGinRoutes(x)
 			a.extractEchoRoutes(x)
+			a.extractMuxRoutes(x)
 		}
 		return true
 	})
 }
 
+// collectMuxPrefixes walks every assignment in the file looking for
+// `x := r.PathPrefix("/a").Subrouter()` style chains and records the
+// resulting prefix for x in a.muxPrefixes. Chains are resolved transitively
+// (x -> "/a", y := x.PathPrefix("/b").Subrouter() -> "/a/b") by repeatedly
+// sweeping the assignments until a pass makes no further progress.
+func (a *Analyzer) collectMuxPrefixes(file *ast.File) {
+	var assigns []*ast.AssignStmt
+	ast.Inspect(file, func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok {
+			assigns = append(assigns, assign)
+		}
+		return true
+	})
+
+	for {
+		progress := false
+		for _, assign := range assigns {
+			if len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+				continue
+			}
+			ident, ok := assign.Lhs[0].(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if _, exists := a.muxPrefixes[ident.Name]; exists {
+				continue
+			}
+			if prefix, ok := a.resolveSubrouterPrefix(assign.Rhs[0]); ok {
+				a.muxPrefixes[ident.Name] = prefix
+				progress = true
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+}
+
+// resolveSubrouterPrefix recognises `<base>.PathPrefix("/x").Subrouter()` and
+// returns the prefix of <base> (already resolved in a.muxPrefixes, or "" for
+// the root router) joined with "/x".
+func (a *Analyzer) resolveSubrouterPrefix(expr ast.Expr) (string, bool) {
+	subrouterCall, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	subrouterSel, ok := subrouterCall.Fun.(*ast.SelectorExpr)
+	if !ok || subrouterSel.Sel.Name != "Subrouter" {
+		return "", false
+	}
+
+	prefixCall, ok := subrouterSel.X.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	prefixSel, ok := prefixCall.Fun.(*ast.SelectorExpr)
+	if !ok || prefixSel.Sel.Name != "PathPrefix" || len(prefixCall.Args) == 0 {
+		return "", false
+	}
+
+	base, ok := prefixSel.X.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	basePrefix := a.muxPrefixes[base.Name] // "" for the root router
+
+	return basePrefix + a.extractStringArg(prefixCall.Args[0]), true
+}
+
 // extractHTTPCalls extracts endpoints from net/http package calls
 func (a *Analyzer) extractHTTPCalls(call *ast.CallExpr) {
 	if fun, ok := call.Fun.(*ast.SelectorExpr); ok {
@@ -83,11 +267,12 @@ func (a *Analyzer) extractHTTPCalls(call *ast.CallExpr) {
 					Path:        path,
 					Method:      "GET", // HandleFunc typically handles GET
 					Description: desc,
+					DataShapes:  a.extractDataShapes(call.Args[1]),
 					CurlExample: fmt.Sprintf("curl %s", path),
 				}
 
 				if path != "" {
-					a.enpoints = append(a.enpoints, doc)
+					a.addEndpoint(doc)
 				}
 			}
 		}
@@ -111,11 +296,12 @@ func (a *Analyzer) extractGinRoutes(call *ast.CallExpr) {
 					Path:        path,
 					Method:      method,
 					Description: desc,
+					DataShapes:  a.extractDataShapes(call.Args[1]),
 					CurlExample: fmt.Sprintf("curl -X %s %s", method, path),
 				}
 
 				if path != "" {
-					a.enpoints = append(a.enpoints, doc)
+					a.addEndpoint(doc)
 				}
 			}
 		}
@@ -132,11 +318,12 @@ func (a *Analyzer) extractGinRoutes(call *ast.CallExpr) {
 				Path:        path,
 				Method:      method,
 				Description: desc,
+				DataShapes:  a.extractDataShapes(call.Args[2]),
 				CurlExample: fmt.Sprintf("curl -X %s %s", method, path),
 			}
 
 			if path != "" && method != "" {
-				a.enpoints = append(a.enpoints, doc)
+				a.addEndpoint(doc)
 			}
 		}
 	}
@@ -156,17 +343,244 @@ func (a *Analyzer) extractEchoRoutes(call *ast.CallExpr) {
 					Path:        path,
 					Method:      method,
 					Description: desc,
+					DataShapes:  a.extractDataShapes(call.Args[1]),
 					CurlExample: fmt.Sprintf("curl -X %s %s", method, path),
 				}
 
 				if path != "" {
-					a.enpoints = append(a.enpoints, doc)
+					a.addEndpoint(doc)
 				}
 			}
 		}
 	}
 }
 
+// extractMuxRoutes extracts endpoints from Gorilla Mux chained calls:
+// r.HandleFunc(path, handler).Methods(m1, m2, ...) and
+// r.Path(path).HandlerFunc(handler).Methods(m1, m2, ...). The receiver of
+// HandleFunc/Path may be the root router or any subrouter resolved by
+// collectMuxPrefixes, in which case its prefix is prepended to path.
+func (a *Analyzer) extractMuxRoutes(call *ast.CallExpr) {
+	methodsSel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || methodsSel.Sel.Name != "Methods" {
+		return
+	}
+
+	inner, ok := methodsSel.X.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	innerSel, ok := inner.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	var path string
+	var handler ast.Expr
+	switch innerSel.Sel.Name {
+	case "HandleFunc":
+		if len(inner.Args) < 2 {
+			return
+		}
+		path = a.muxPrefixFor(innerSel.X) + a.extractStringArg(inner.Args[0])
+		handler = inner.Args[1]
+	case "HandlerFunc":
+		if len(inner.Args) == 0 {
+			return
+		}
+		pathCall, ok := innerSel.X.(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		pathSel, ok := pathCall.Fun.(*ast.SelectorExpr)
+		if !ok || pathSel.Sel.Name != "Path" || len(pathCall.Args) == 0 {
+			return
+		}
+		path = a.muxPrefixFor(pathSel.X) + a.extractStringArg(pathCall.Args[0])
+		handler = inner.Args[0]
+	default:
+		return
+	}
+
+	if path == "" {
+		return
+	}
+	pathParams := a.extractPathParams(path)
+	desc := a.findHandlerComment(handler)
+	shapes := a.extractDataShapes(handler)
+
+	for _, methodArg := range call.Args {
+		method := a.extractStringArg(methodArg)
+		if method == "" {
+			continue
+		}
+		doc := APIDocumentation{
+			Path:        path,
+			Method:      method,
+			Description: desc,
+			DataShapes:  shapes,
+			PathParams:  pathParams,
+			CurlExample: fmt.Sprintf("curl -X %s %s", method, a.examplePath(path, pathParams)),
+		}
+		a.addEndpoint(doc)
+	}
+}
+
+// walkChiBlock walks node for github.com/go-chi/chi route registrations:
+// r.Get/Post/Put/Delete/Patch(path, handler), r.Method(verb, path, handler),
+// r.Route(prefix, func(r chi.Router){...}) with nested sub-routes,
+// r.Mount(prefix, subrouter), and r.With(mw...).Get(...) chains. Route
+// closures are recursed into manually with the prefix pushed onto
+// a.chiPrefixStack, then the CallExpr is skipped (return false) so this
+// walk doesn't also descend into it at the outer prefix depth.
+func (a *Analyzer) walkChiBlock(node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		switch sel.Sel.Name {
+		case "Route":
+			if len(call.Args) != 2 {
+				return true
+			}
+			lit, ok := call.Args[1].(*ast.FuncLit)
+			if !ok || lit.Body == nil {
+				return true
+			}
+			a.chiPrefixStack = append(a.chiPrefixStack, a.extractStringArg(call.Args[0]))
+			a.walkChiBlock(lit.Body)
+			a.chiPrefixStack = a.chiPrefixStack[:len(a.chiPrefixStack)-1]
+			return false
+		case "Mount":
+			if len(call.Args) != 2 {
+				return true
+			}
+			a.extractChiMount(call.Args[0], call.Args[1])
+		case "Method":
+			if len(call.Args) != 3 {
+				return true
+			}
+			a.extractChiRoute(a.extractStringArg(call.Args[0]), call.Args[1], call.Args[2], a.chiMiddlewareFor(sel.X))
+		case "Get", "Post", "Put", "Delete", "Patch", "Head", "Options":
+			if len(call.Args) != 2 {
+				return true
+			}
+			a.extractChiRoute(strings.ToUpper(sel.Sel.Name), call.Args[0], call.Args[1], a.chiMiddlewareFor(sel.X))
+		}
+		return true
+	})
+}
+
+// extractChiRoute records a single Chi route registration at the current
+// prefix stack depth.
+func (a *Analyzer) extractChiRoute(method string, pathArg, handlerArg ast.Expr, middleware []string) {
+	path := a.chiPrefix() + a.extractStringArg(pathArg)
+	if path == "" || method == "" {
+		return
+	}
+
+	pathParams := a.extractPathParams(path)
+	doc := APIDocumentation{
+		Path:        path,
+		Method:      method,
+		Description: a.findHandlerComment(handlerArg),
+		DataShapes:  a.extractDataShapes(handlerArg),
+		PathParams:  pathParams,
+		Middleware:  middleware,
+		CurlExample: fmt.Sprintf("curl -X %s %s", method, a.examplePath(path, pathParams)),
+	}
+	a.addEndpoint(doc)
+}
+
+// mountMethod is the pseudo-method recorded for an r.Mount(...) registration.
+// It isn't a real HTTP verb, so toOpenAPIEndpoints filters it out before
+// building the OpenAPI document or generating a client SDK.
+const mountMethod = "MOUNT"
+
+// extractChiMount records an r.Mount(prefix, subrouter) registration. The
+// sub-router's own routes aren't enumerable from the mount point alone, so
+// this just documents the mount itself.
+func (a *Analyzer) extractChiMount(pathArg, subrouterArg ast.Expr) {
+	path := a.chiPrefix() + a.extractStringArg(pathArg)
+	if path == "" {
+		return
+	}
+
+	a.addEndpoint(APIDocumentation{
+		Path:        path,
+		Method:      mountMethod,
+		Description: fmt.Sprintf("Mounted sub-router: %s", a.extractHandlerName(subrouterArg)),
+		CurlExample: fmt.Sprintf("curl %s", path),
+	})
+}
+
+// chiPrefix joins the currently nested r.Route(...) prefixes.
+func (a *Analyzer) chiPrefix() string {
+	return strings.Join(a.chiPrefixStack, "")
+}
+
+// chiMiddlewareFor walks back through chained r.With(mw...) calls from
+// receiver to the base router, collecting every middleware name along the
+// way.
+func (a *Analyzer) chiMiddlewareFor(receiver ast.Expr) []string {
+	var middleware []string
+	for {
+		call, ok := receiver.(*ast.CallExpr)
+		if !ok {
+			return middleware
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "With" {
+			return middleware
+		}
+		for _, arg := range call.Args {
+			if name := a.extractHandlerName(arg); name != "" {
+				middleware = append(middleware, name)
+			}
+		}
+		receiver = sel.X
+	}
+}
+
+// muxPrefixFor returns the resolved subrouter prefix for the receiver of a
+// HandleFunc/Path call, or "" if the receiver is the root router or unknown.
+func (a *Analyzer) muxPrefixFor(receiver ast.Expr) string {
+	ident, ok := receiver.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return a.muxPrefixes[ident.Name]
+}
+
+// extractPathParams finds {param} style placeholders in a route path.
+func (a *Analyzer) extractPathParams(path string) []PathParam {
+	matches := muxPathParamPattern.FindAllStringSubmatch(path, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	params := make([]PathParam, 0, len(matches))
+	for _, m := range matches {
+		params = append(params, PathParam{Name: m[1]})
+	}
+	return params
+}
+
+// examplePath replaces {param} placeholders with sample values so the curl
+// example is directly runnable.
+func (a *Analyzer) examplePath(path string, params []PathParam) string {
+	example := path
+	for _, p := range params {
+		example = strings.Replace(example, "{"+p.Name+"}", "1", 1)
+	}
+	return muxPathParamPattern.ReplaceAllString(example, "1")
+}
+
 // Helper functions
 func (a *Analyzer) extractStringArg(arg ast.Expr) string {
 	if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
@@ -176,27 +590,399 @@ func (a *Analyzer) extractStringArg(arg ast.Expr) string {
 	return ""
 }
 
-func (a *Analyzer) extractHandlerName(arg ast.Expr) string {
-	if ident, ok := arg.(*ast.Ident); ok {
-		return ident.Name
+// extractStatusCode resolves a c.JSON(code, ...) style first argument to its
+// integer status, recognizing both int literals (c.JSON(201, x)) and named
+// net/http constants (c.JSON(http.StatusCreated, x)) via the package's
+// resolved type information. It returns 0 when the status can't be
+// statically resolved.
+func (a *Analyzer) extractStatusCode(arg ast.Expr) int {
+	if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.INT {
+		if n, err := strconv.Atoi(lit.Value); err == nil {
+			return n
+		}
+	}
+	sel, ok := arg.(*ast.SelectorExpr)
+	if !ok || a.typesInfo == nil {
+		return 0
+	}
+	obj, ok := a.typesInfo.Uses[sel.Sel]
+	if !ok {
+		return 0
 	}
-	if sel, ok := arg.(*ast.SelectorExpr); ok {
-		return sel.Sel.Name // Extract just the function name
+	c, ok := obj.(*types.Const)
+	if !ok {
+		return 0
+	}
+	n, ok := constant.Int64Val(c.Val())
+	if !ok {
+		return 0
+	}
+	return int(n)
+}
+
+// extractHandlerName resolves an expression passed where a handler or
+// sub-router is expected to the name most useful for looking it up:
+// a bare identifier, a qualified selector, or, for a CallExpr, either the
+// single argument it wraps (e.g. http.HandlerFunc(pingHandler) resolves to
+// "pingHandler" so its doc comment is still found) or, if that yields
+// nothing, the called function's own name (e.g. chiDebugRouter()).
+func (a *Analyzer) extractHandlerName(arg ast.Expr) string {
+	switch e := arg.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.CallExpr:
+		if len(e.Args) == 1 {
+			if name := a.extractHandlerName(e.Args[0]); name != "" {
+				return name
+			}
+		}
+		return a.extractHandlerName(e.Fun)
 	}
 	return ""
 }
 
+// resolveHandlerFuncDecl looks up the declaration behind a handler argument
+// (a bare identifier or a qualified selector) in the current package's
+// handlerFuncs index.
+func (a *Analyzer) resolveHandlerFuncDecl(arg ast.Expr) *ast.FuncDecl {
+	name := a.extractHandlerName(arg)
+	if name == "" {
+		return nil
+	}
+	return a.handlerFuncs[name]
+}
+
+// findHandlerComment returns the handler function's doc comment, falling
+// back to the comment leading its body (bodyComments), and then to a
+// generic description when the handler can't be resolved or has neither.
 func (a *Analyzer) findHandlerComment(arg ast.Expr) string {
-	// This is a simplified implementation - in a real application,
-	// you'd need to parse the comment groups associated with the function
+	decl := a.resolveHandlerFuncDecl(arg)
+	if decl == nil {
+		return "Handler function"
+	}
+	if decl.Doc != nil {
+		if text := strings.TrimSpace(decl.Doc.Text()); text != "" {
+			return text
+		}
+	}
+	if text, ok := a.bodyComments[decl.Name.Name]; ok {
+		return text
+	}
 	return "Handler function"
 }
 
+// extractDataShapes walks the handler's body for JSON request/response
+// patterns (json.NewDecoder(r.Body).Decode(&x), json.Unmarshal(b, &x),
+// json.NewEncoder(w).Encode(x), c.JSON(code, x), c.Bind(&x)) and reflects
+// each captured variable's type into a DataShape.
+func (a *Analyzer) extractDataShapes(arg ast.Expr) []DataShape {
+	decl := a.resolveHandlerFuncDecl(arg)
+	if decl == nil || decl.Body == nil || a.typesInfo == nil {
+		return nil
+	}
+
+	var shapes []DataShape
+	// seen dedupes by (Name, IsRequest) rather than bare Name: map- and
+	// slice-of-unnamed-type bodies all share the empty Name, so a handler
+	// with both a map request body and a map response body would otherwise
+	// collide on seen[""] and silently lose one of them.
+	type shapeKey struct {
+		name      string
+		isRequest bool
+	}
+	seen := make(map[shapeKey]bool)
+	add := func(shape *DataShape) {
+		if shape == nil {
+			return
+		}
+		key := shapeKey{shape.Name, shape.IsRequest}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		shapes = append(shapes, *shape)
+	}
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		switch sel.Sel.Name {
+		case "Decode":
+			if isJSONChain(sel.X, "NewDecoder") && len(call.Args) == 1 {
+				add(a.shapeFromAddr(call.Args[0], "Request"))
+			}
+		case "Unmarshal":
+			if isJSONIdent(sel.X) && len(call.Args) == 2 {
+				add(a.shapeFromAddr(call.Args[1], "Request"))
+			}
+		case "Encode":
+			if isJSONChain(sel.X, "NewEncoder") && len(call.Args) == 1 {
+				add(a.shapeFromExpr(call.Args[0], "Response"))
+			}
+		case "JSON":
+			if len(call.Args) == 2 {
+				shape := a.shapeFromExpr(call.Args[1], "Response")
+				if shape != nil {
+					shape.StatusCode = a.extractStatusCode(call.Args[0])
+				}
+				add(shape)
+			}
+		case "Bind":
+			if len(call.Args) == 1 {
+				add(a.shapeFromAddr(call.Args[0], "Request"))
+			}
+		}
+		return true
+	})
+
+	return shapes
+}
+
+// isJSONIdent reports whether expr is the bare "json" package identifier.
+func isJSONIdent(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "json"
+}
+
+// isJSONChain reports whether expr is a call to encoding/json's
+// constructor (e.g. json.NewDecoder(...) or json.NewEncoder(...)).
+func isJSONChain(expr ast.Expr, constructor string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == constructor && isJSONIdent(sel.X)
+}
+
+// shapeFromAddr resolves the type behind a &x argument, as used by
+// Decode/Unmarshal/Bind which write into a pointer.
+func (a *Analyzer) shapeFromAddr(arg ast.Expr, role string) *DataShape {
+	unary, ok := arg.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return nil
+	}
+	return a.shapeFromType(a.typesInfo.TypeOf(unary.X), role)
+}
+
+// shapeFromExpr resolves the type of an argument passed by value, as used
+// by Encode/c.JSON which read from the value directly.
+func (a *Analyzer) shapeFromExpr(arg ast.Expr, role string) *DataShape {
+	return a.shapeFromType(a.typesInfo.TypeOf(arg), role)
+}
+
+// shapeFromType reflects a resolved type into a DataShape, with a
+// JSON-tag-aware example rendered as its Shape. t may be a (possibly
+// pointer-wrapped) named struct, or a slice/array/map of one, so that
+// handlers encoding a list ([]User) or a bare map (map[string]string) still
+// document a response shape instead of being silently dropped.
+func (a *Analyzer) shapeFromType(t types.Type, role string) *DataShape {
+	t = unwrapPointer(t)
+	if t == nil {
+		return nil
+	}
+
+	switch t.Underlying().(type) {
+	case *types.Struct, *types.Slice, *types.Array, *types.Map:
+	default:
+		return nil
+	}
+
+	example, err := json.Marshal(exampleValue(t))
+	if err != nil {
+		return nil
+	}
+
+	name := shapeName(t)
+	desc := fmt.Sprintf("%s body", role)
+	if name != "" {
+		desc = fmt.Sprintf("%s body inferred from %s", role, name)
+	}
+
+	return &DataShape{
+		Name:        name,
+		Description: desc,
+		Shape:       string(example),
+		IsRequest:   role == "Request",
+	}
+}
+
+// unwrapPointer follows t through any number of pointer indirections,
+// returning the first non-pointer type (or nil, for a nil t).
+func unwrapPointer(t types.Type) types.Type {
+	if t == nil {
+		return nil
+	}
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			return t
+		}
+		t = ptr.Elem()
+	}
+}
+
+// shapeName returns the named struct identifying t's OpenAPI component name:
+// t itself if it's a named struct, or its element type if t is an unnamed
+// slice/array of one. It returns "" for maps, for named non-struct types
+// (e.g. type Users []User), and for collections of unnamed or basic element
+// types, which fall back to a path-derived component name.
+func shapeName(t types.Type) string {
+	if named, ok := t.(*types.Named); ok {
+		if _, ok := named.Underlying().(*types.Struct); ok {
+			return named.Obj().Name()
+		}
+		return ""
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return shapeName(unwrapPointer(u.Elem()))
+	case *types.Array:
+		return shapeName(unwrapPointer(u.Elem()))
+	}
+	return ""
+}
+
+// structExample builds a JSON-tag-aware sample value for a struct type,
+// recursing into nested structs, slices, and arrays.
+func structExample(strct *types.Struct) map[string]interface{} {
+	example := make(map[string]interface{})
+	for i := 0; i < strct.NumFields(); i++ {
+		field := strct.Field(i)
+		if !field.Exported() {
+			continue
+		}
+		name, omit := jsonFieldName(strct.Tag(i), field.Name())
+		if omit {
+			continue
+		}
+		example[name] = exampleValue(field.Type())
+	}
+	return example
+}
+
+// jsonFieldName resolves a struct field's JSON name from its tag, reporting
+// omit=true for fields tagged json:"-".
+func jsonFieldName(tag, fallback string) (name string, omit bool) {
+	value := reflect.StructTag(tag).Get("json")
+	if value == "" {
+		return fallback, false
+	}
+	parts := strings.Split(value, ",")
+	if parts[0] == "-" {
+		return "", true
+	}
+	if parts[0] == "" {
+		return fallback, false
+	}
+	return parts[0], false
+}
+
+// exampleValue produces a placeholder JSON value matching a field's type.
+func exampleValue(t types.Type) interface{} {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return false
+		case u.Info()&types.IsFloat != 0:
+			return 0.0
+		case u.Info()&types.IsNumeric != 0:
+			return 0
+		default:
+			return ""
+		}
+	case *types.Slice:
+		return []interface{}{exampleValue(u.Elem())}
+	case *types.Array:
+		return []interface{}{exampleValue(u.Elem())}
+	case *types.Struct:
+		return structExample(u)
+	case *types.Pointer:
+		return exampleValue(u.Elem())
+	case *types.Map:
+		return map[string]interface{}{}
+	default:
+		return nil
+	}
+}
+
+// addEndpoint records a discovered endpoint, keyed both in the flat
+// enpoints slice and by the file currently being analyzed.
+func (a *Analyzer) addEndpoint(doc APIDocumentation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.enpoints = append(a.enpoints, doc)
+	a.byFile[a.currentFile] = append(a.byFile[a.currentFile], doc)
+}
+
 // GetEndpoints returns the collected endpoints
 func (a *Analyzer) GetEndpoints() []APIDocumentation {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	return a.enpoints
 }
 
+// ByFile returns the collected endpoints indexed by source file, so a
+// watcher can tell which endpoints belonged to a file that changed or was
+// removed.
+func (a *Analyzer) ByFile() map[string][]APIDocumentation {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.byFile
+}
+
+// toOpenAPIEndpoints converts the analyzer's own APIDocumentation slice into
+// the plain openapi.Endpoint shape the openapi package builds a document
+// from, since a package cannot import package main. Mount registrations are
+// dropped here: "MOUNT" isn't a real HTTP method, so it has no legal
+// representation as an OpenAPI Path Item field or a codegen'd client call.
+func toOpenAPIEndpoints(docs []APIDocumentation) []openapi.Endpoint {
+	endpoints := make([]openapi.Endpoint, 0, len(docs))
+	for _, doc := range docs {
+		if doc.Method == mountMethod {
+			continue
+		}
+		shapes := make([]openapi.Shape, 0, len(doc.DataShapes))
+		for _, shape := range doc.DataShapes {
+			shapes = append(shapes, openapi.Shape{
+				Name:        shape.Name,
+				Description: shape.Description,
+				Shape:       shape.Shape,
+				StatusCode:  shape.StatusCode,
+				IsRequest:   shape.IsRequest,
+			})
+		}
+		endpoints = append(endpoints, openapi.Endpoint{
+			Path:        doc.Path,
+			Method:      doc.Method,
+			Description: doc.Description,
+			DataShapes:  shapes,
+		})
+	}
+	return endpoints
+}
+
+// buildOpenAPIDocument analyzes dir and converts the discovered endpoints
+// into an OpenAPI 3.0 document.
+func buildOpenAPIDocument(dir string) (*openapi.Document, error) {
+	analyzer := NewAnalyzer()
+	if err := analyzer.ParseDirectory(dir); err != nil {
+		return nil, err
+	}
+	endpoints := toOpenAPIEndpoints(analyzer.GetEndpoints())
+	return openapi.Build("documatic", "1.0.0", endpoints), nil
+}
+
 func main() {
 	analyzer := NewAnalyzer()
 
@@ -277,11 +1063,57 @@ func main() {
 		json.NewEncoder(w).Encode(docs)
 	})
 
+	http.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		dir := r.URL.Query().Get("dir")
+		if dir == "" {
+			dir = "."
+		}
+
+		doc, err := buildOpenAPIDocument(dir)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error analyzing directory: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(doc)
+	})
+
+	http.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		dir := r.URL.Query().Get("dir")
+		if dir == "" {
+			dir = "."
+		}
+
+		doc, err := buildOpenAPIDocument(dir)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error analyzing directory: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		yaml, err := doc.YAML()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error rendering YAML: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/yaml")
+		fmt.Fprint(w, yaml)
+	})
+
+	registerWatchHandlers()
+	registerGenerateHandler()
+
 	fmt.Println("Documentation generator running on http://localhost:9090")
 	fmt.Println("Endpoints:")
 	fmt.Println("  POST /analyze?dir=./path/to/go/project - Generate API docs")
 	fmt.Println("  GET  /health - Health check")
 	fmt.Println("  GET  /docs - This service's documentation")
+	fmt.Println("  GET  /openapi.json?dir=./path/to/go/project - OpenAPI 3.0 document")
+	fmt.Println("  GET  /openapi.yaml?dir=./path/to/go/project - OpenAPI 3.0 document (YAML)")
+	fmt.Println("  POST /watch?dir=./path/to/go/project - Watch a directory for changes")
+	fmt.Println("  GET  /stream?dir=./path/to/go/project - Stream endpoint changes (SSE)")
+	fmt.Println("  POST /generate?lang=go|ts|python&dir=./path/to/go/project - Generate a client SDK zip")
 
 	log.Fatal(http.ListenAndServe(":9090", nil))
 }
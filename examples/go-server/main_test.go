@@ -0,0 +1,269 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"testing"
+)
+
+// parseFixture parses the Gorilla Mux sample in server/doc_parser_examples.go
+// and runs it through the same analysis steps ParseDirectory would, without
+// needing a full go/packages load (and its gorilla/mux dependency) since
+// collectMuxPrefixes/extractMuxRoutes don't require type information.
+func parseFixture(t *testing.T) *Analyzer {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	path := filepath.Join("..", "..", "server", "doc_parser_examples.go")
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parsing fixture: %v", err)
+	}
+
+	a := NewAnalyzer()
+	a.fset = fset
+	a.collectHandlerFuncs([]*ast.File{file})
+	a.analyzeFile(file)
+	return a
+}
+
+// parseChiFixture parses the Chi sample in server/chi_router_example.go the
+// same way parseFixture does for the mux one.
+func parseChiFixture(t *testing.T) *Analyzer {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	path := filepath.Join("..", "..", "server", "chi_router_example.go")
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parsing fixture: %v", err)
+	}
+
+	a := NewAnalyzer()
+	a.fset = fset
+	a.collectHandlerFuncs([]*ast.File{file})
+	a.analyzeFile(file)
+	return a
+}
+
+func TestExtractChiRoutesFromFixture(t *testing.T) {
+	a := parseChiFixture(t)
+	endpoints := a.GetEndpoints()
+
+	want := map[string][]string{
+		"/users":             {"GET", "POST"},
+		"/admin":             {"GET"},
+		"/ping":              {"GET"},
+		"/api/v1/health":     {"GET"},
+		"/api/v1/users/{id}": {"GET"},
+		"/debug":             {"MOUNT"},
+	}
+
+	got := make(map[string][]string)
+	for _, ep := range endpoints {
+		got[ep.Path] = append(got[ep.Path], ep.Method)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d distinct paths, want %d: %v", len(got), len(want), got)
+	}
+	for path, methods := range want {
+		if len(got[path]) != len(methods) {
+			t.Errorf("path %s: got methods %v, want %v", path, got[path], methods)
+		}
+	}
+}
+
+func TestExtractChiRoutesNestedRoutePrefix(t *testing.T) {
+	a := parseChiFixture(t)
+
+	for _, ep := range a.GetEndpoints() {
+		if ep.Path == "/api/v1/users/{id}" && ep.Method == "GET" {
+			if len(ep.PathParams) != 1 || ep.PathParams[0].Name != "id" {
+				t.Fatalf("got path params %v, want [id]", ep.PathParams)
+			}
+			want := "curl -X GET /api/v1/users/1"
+			if ep.CurlExample != want {
+				t.Errorf("got curl example %q, want %q (id substituted via examplePath, like extractMuxRoutes does)", ep.CurlExample, want)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected GET /api/v1/users/{id} among discovered endpoints, nested under both Route(\"/api/v1\") and Route(\"/users\")")
+}
+
+func TestExtractChiRoutesMount(t *testing.T) {
+	a := parseChiFixture(t)
+
+	for _, ep := range a.GetEndpoints() {
+		if ep.Path == "/debug" && ep.Method == mountMethod {
+			if ep.Description != "Mounted sub-router: chiDebugRouter" {
+				t.Errorf("got description %q, want it to name the mounted sub-router func", ep.Description)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected the r.Mount(\"/debug\", chiDebugRouter()) registration among discovered endpoints")
+}
+
+func TestChiMiddlewareForCollectsWithChain(t *testing.T) {
+	a := parseChiFixture(t)
+
+	for _, ep := range a.GetEndpoints() {
+		if ep.Path == "/admin" && ep.Method == "GET" {
+			want := []string{"loggingMiddleware", "authMiddleware"}
+			if len(ep.Middleware) != len(want) {
+				t.Fatalf("got middleware %v, want %v", ep.Middleware, want)
+			}
+			for i, name := range want {
+				if ep.Middleware[i] != name {
+					t.Errorf("middleware[%d] = %q, want %q", i, ep.Middleware[i], name)
+				}
+			}
+			return
+		}
+	}
+	t.Fatalf("expected GET /admin among discovered endpoints")
+}
+
+func TestExtractMuxRoutesFromFixture(t *testing.T) {
+	a := parseFixture(t)
+	endpoints := a.GetEndpoints()
+
+	want := map[string][]string{
+		"/users":                      {"GET", "POST"},
+		"/users/{id}":                 {"GET", "PUT", "DELETE"},
+		"/posts":                      {"GET", "POST"},
+		"/comments":                   {"GET", "POST", "PUT"},
+		"/articles/{category}/{slug}": {"GET"},
+		"/api/v1/health":              {"GET"},
+		"/api/v1/status":              {"GET"},
+	}
+
+	got := make(map[string][]string)
+	for _, ep := range endpoints {
+		got[ep.Path] = append(got[ep.Path], ep.Method)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d distinct paths, want %d: %v", len(got), len(want), got)
+	}
+	for path, methods := range want {
+		if len(got[path]) != len(methods) {
+			t.Errorf("path %s: got methods %v, want %v", path, got[path], methods)
+		}
+	}
+}
+
+func TestCollectMuxPrefixesResolvesSubrouter(t *testing.T) {
+	a := parseFixture(t)
+
+	var sawHealth bool
+	for _, ep := range a.GetEndpoints() {
+		if ep.Path == "/api/v1/health" && ep.Method == "GET" {
+			sawHealth = true
+		}
+		if ep.Path == "/health" {
+			t.Errorf("route registered on the api subrouter should resolve to /api/v1/health, not /health")
+		}
+	}
+	if !sawHealth {
+		t.Errorf("expected /api/v1/health to be discovered via the api := r.PathPrefix(\"/api/v1\").Subrouter() chain")
+	}
+}
+
+// TestShapeFromTypeHandlesSliceAndMapBodies exercises shapeFromType against
+// the server/ sample app's net/http handlers, which return a slice
+// ([]User) and a bare map (map[string]string) rather than a single named
+// struct. ParseDirectory needs full type information, so unlike the mux
+// fixture tests above this loads the package with go/packages.
+func TestShapeFromTypeHandlesSliceAndMapBodies(t *testing.T) {
+	a := NewAnalyzer()
+	if err := a.ParseDirectory(filepath.Join("..", "..", "server")); err != nil {
+		t.Fatalf("ParseDirectory: %v", err)
+	}
+
+	// The sample directory registers /users and /health against several
+	// router frameworks, so match getUsersHandler/healthHandler specifically
+	// by their doc comment rather than asserting over every /users or
+	// /health endpoint.
+	var gotUsers, gotHealth bool
+	for _, ep := range a.GetEndpoints() {
+		switch ep.Description {
+		case "Handle GET /users":
+			if len(ep.DataShapes) != 1 || ep.DataShapes[0].Name != "User" {
+				t.Errorf("getUsersHandler: got shapes %v, want one shape named User", ep.DataShapes)
+			}
+			gotUsers = true
+		case "Handle GET /health":
+			if len(ep.DataShapes) != 1 {
+				t.Errorf("healthHandler: got shapes %v, want one shape for the map[string]string body", ep.DataShapes)
+			}
+			gotHealth = true
+		}
+	}
+	if !gotUsers {
+		t.Fatalf("expected getUsersHandler's GET /users among discovered endpoints")
+	}
+	if !gotHealth {
+		t.Fatalf("expected healthHandler's GET /health among discovered endpoints")
+	}
+}
+
+// TestExtractDataShapesKeepsRequestAndResponseMapBodies exercises
+// echoMapHandler, which decodes a map[string]string request body and
+// encodes a different map[string]string response body. Both shapes have
+// an empty Name (shapeFromType can't name an unnamed map type), so deduping
+// extractDataShapes's add/seen by bare Name alone would collide the two on
+// seen[""] and silently drop one.
+func TestExtractDataShapesKeepsRequestAndResponseMapBodies(t *testing.T) {
+	a := NewAnalyzer()
+	if err := a.ParseDirectory(filepath.Join("..", "..", "server")); err != nil {
+		t.Fatalf("ParseDirectory: %v", err)
+	}
+
+	for _, ep := range a.GetEndpoints() {
+		if ep.Description != "Handle POST /echo-map" {
+			continue
+		}
+		if len(ep.DataShapes) != 2 {
+			t.Fatalf("echoMapHandler: got shapes %v, want one request and one response shape", ep.DataShapes)
+		}
+		var gotRequest, gotResponse bool
+		for _, shape := range ep.DataShapes {
+			if shape.Name != "" {
+				t.Errorf("echoMapHandler: got shape name %q, want empty for an unnamed map body", shape.Name)
+			}
+			if shape.IsRequest {
+				gotRequest = true
+			} else {
+				gotResponse = true
+			}
+		}
+		if !gotRequest || !gotResponse {
+			t.Fatalf("echoMapHandler: got shapes %v, want both a request and a response shape to survive dedup", ep.DataShapes)
+		}
+		return
+	}
+	t.Fatalf("expected echoMapHandler's POST /echo-map among discovered endpoints")
+}
+
+func TestExtractMuxRoutesPathParams(t *testing.T) {
+	a := parseFixture(t)
+
+	for _, ep := range a.GetEndpoints() {
+		if ep.Path != "/articles/{category}/{slug}" {
+			continue
+		}
+		if len(ep.PathParams) != 2 {
+			t.Fatalf("got %d path params, want 2: %v", len(ep.PathParams), ep.PathParams)
+		}
+		if ep.PathParams[0].Name != "category" || ep.PathParams[1].Name != "slug" {
+			t.Errorf("got path params %v, want [category slug]", ep.PathParams)
+		}
+		return
+	}
+	t.Fatalf("expected /articles/{category}/{slug} among discovered endpoints")
+}
@@ -0,0 +1,381 @@
+// Package openapi converts the analyzer's collected endpoints into an
+// OpenAPI 3.0 document consumable by Swagger UI, Postman, and code
+// generators. It takes a plain []Endpoint rather than importing the
+// analyzer's own types directly, since the analyzer lives in package main
+// and main packages cannot be imported.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Endpoint is the minimal endpoint description the analyzer feeds into Build.
+type Endpoint struct {
+	Path        string
+	Method      string
+	Description string
+	DataShapes  []Shape
+}
+
+// Shape mirrors the analyzer's DataShape: a named JSON snippet describing a
+// request or response body.
+type Shape struct {
+	Name        string
+	Description string
+	Shape       string
+	// StatusCode is the response's HTTP status, or 0 if unresolved (treated
+	// as 200 by Build).
+	StatusCode int
+	// IsRequest reports whether the analyzer captured this shape from a
+	// request-side call, rather than guessing it from the struct's name.
+	IsRequest bool
+}
+
+// Document is a minimal OpenAPI 3.0 document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info holds the document's title and version, as required by OpenAPI 3.0.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem maps a lowercase HTTP method to its operation.
+type PathItem map[string]Operation
+
+// Operation describes a single method on a path.
+type Operation struct {
+	Summary     string              `json:"summary,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes a path parameter derived from a {param} or :param
+// placeholder in the route.
+type Parameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	Schema   Schema `json:"schema"`
+}
+
+// RequestBody wraps the JSON schema expected in the request body.
+type RequestBody struct {
+	Content map[string]MediaType `json:"content"`
+}
+
+// Response describes a single response, optionally with a JSON body.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType wraps the schema for a single content type.
+type MediaType struct {
+	Schema SchemaRef `json:"schema"`
+}
+
+// SchemaRef is either a $ref to a components.schemas entry or, if Ref is
+// empty, left for the caller to ignore.
+type SchemaRef struct {
+	Ref string `json:"$ref"`
+}
+
+// Schema is a JSON Schema subset sufficient to describe the shapes the
+// analyzer infers: objects, arrays, and the JSON scalar types.
+type Schema struct {
+	Type       string             `json:"type,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+}
+
+// Components holds the schemas referenced by $ref throughout the document.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// pathParamPattern matches both Gorilla Mux ({id}) and Gin/Echo (:id) path
+// parameter syntax.
+var pathParamPattern = regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*)\}|:([a-zA-Z_][a-zA-Z0-9_]*)`)
+
+// Build converts a flat list of endpoints into an OpenAPI 3.0 document,
+// grouping operations by normalized path and lifting any DataShapes into
+// components.schemas.
+func Build(title, version string, endpoints []Endpoint) *Document {
+	doc := &Document{
+		OpenAPI:    "3.0.0",
+		Info:       Info{Title: title, Version: version},
+		Paths:      make(map[string]PathItem),
+		Components: Components{Schemas: make(map[string]*Schema)},
+	}
+
+	for _, ep := range endpoints {
+		path := normalizePath(ep.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = PathItem{}
+		}
+
+		op := Operation{
+			Summary:    ep.Description,
+			Parameters: pathParameters(path),
+			Responses:  map[string]Response{},
+		}
+
+		for _, shape := range ep.DataShapes {
+			schema := schemaFromJSON(shape.Shape)
+			if schema == nil {
+				continue
+			}
+			name := componentName(shape.Name, ep.Method, path)
+			doc.Components.Schemas[name] = schema
+			ref := MediaType{Schema: SchemaRef{Ref: "#/components/schemas/" + name}}
+
+			if shape.IsRequest && canHaveBody(ep.Method) {
+				op.RequestBody = &RequestBody{Content: map[string]MediaType{"application/json": ref}}
+			} else {
+				op.Responses[responseStatus(shape.StatusCode)] = Response{
+					Description: "Successful response",
+					Content:     map[string]MediaType{"application/json": ref},
+				}
+			}
+		}
+		if len(op.Responses) == 0 {
+			op.Responses["200"] = Response{Description: "Successful response"}
+		}
+
+		item[strings.ToLower(ep.Method)] = op
+		doc.Paths[path] = item
+	}
+
+	return doc
+}
+
+// YAML renders the document as YAML. It round-trips through JSON and walks
+// the resulting generic value, emitting minimal block-style YAML, so the
+// analyzer doesn't need a third-party YAML dependency.
+func (d *Document) YAML() (string, error) {
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", err
+	}
+	return strings.Join(yamlLines(value, 0), "\n") + "\n", nil
+}
+
+func normalizePath(path string) string {
+	return pathParamPattern.ReplaceAllStringFunc(path, func(m string) string {
+		name := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSuffix(m, "}"), "{"), ":")
+		return "{" + name + "}"
+	})
+}
+
+func pathParameters(normalizedPath string) []Parameter {
+	matches := regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*)\}`).FindAllStringSubmatch(normalizedPath, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	params := make([]Parameter, 0, len(matches))
+	for _, m := range matches {
+		params = append(params, Parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   Schema{Type: "string"},
+		})
+	}
+	return params
+}
+
+// responseStatus renders a shape's resolved status code as a responses map
+// key, defaulting to "200" when the analyzer couldn't resolve one.
+func responseStatus(code int) string {
+	if code == 0 {
+		return "200"
+	}
+	return strconv.Itoa(code)
+}
+
+func canHaveBody(method string) bool {
+	switch strings.ToUpper(method) {
+	case "POST", "PUT", "PATCH":
+		return true
+	default:
+		return false
+	}
+}
+
+func componentName(shapeName, method, path string) string {
+	if shapeName != "" {
+		return shapeName
+	}
+	return strings.Title(strings.ToLower(method)) + sanitizeName(path)
+}
+
+func sanitizeName(path string) string {
+	var sb strings.Builder
+	capitalizeNext := true
+	for _, r := range path {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9':
+			if capitalizeNext {
+				sb.WriteRune(r - 32*boolToInt(r >= 'a' && r <= 'z'))
+				capitalizeNext = false
+			} else {
+				sb.WriteRune(r)
+			}
+		default:
+			capitalizeNext = true
+		}
+	}
+	return sb.String()
+}
+
+func boolToInt(b bool) rune {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// schemaFromJSON parses a DataShape's raw JSON snippet and infers a Schema
+// from its shape: object keys become properties, arrays infer from their
+// first element, and scalars map to the matching JSON Schema type.
+func schemaFromJSON(raw string) *Schema {
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil
+	}
+	return inferSchema(value)
+}
+
+func inferSchema(value interface{}) *Schema {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		props := make(map[string]*Schema, len(v))
+		for k, val := range v {
+			props[k] = inferSchema(val)
+		}
+		return &Schema{Type: "object", Properties: props}
+	case []interface{}:
+		if len(v) == 0 {
+			return &Schema{Type: "array", Items: &Schema{Type: "object"}}
+		}
+		return &Schema{Type: "array", Items: inferSchema(v[0])}
+	case string:
+		return &Schema{Type: "string"}
+	case bool:
+		return &Schema{Type: "boolean"}
+	case float64:
+		if v == float64(int64(v)) {
+			return &Schema{Type: "integer"}
+		}
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{Type: "object"}
+	}
+}
+
+func yamlLines(value interface{}, indent int) []string {
+	pad := strings.Repeat("  ", indent)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return []string{pad + "{}"}
+		}
+		var lines []string
+		for _, k := range sortedKeys(v) {
+			lines = append(lines, yamlMapEntry(k, v[k], indent)...)
+		}
+		return lines
+	case []interface{}:
+		if len(v) == 0 {
+			return []string{pad + "[]"}
+		}
+		var lines []string
+		for _, item := range v {
+			itemLines := yamlLines(item, indent+1)
+			first := strings.TrimPrefix(itemLines[0], strings.Repeat("  ", indent+1))
+			lines = append(lines, pad+"- "+first)
+			lines = append(lines, itemLines[1:]...)
+		}
+		return lines
+	default:
+		return []string{pad + yamlScalar(v)}
+	}
+}
+
+func yamlMapEntry(key string, value interface{}, indent int) []string {
+	pad := strings.Repeat("  ", indent)
+	key = yamlKey(key)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return []string{pad + key + ": {}"}
+		}
+		return append([]string{pad + key + ":"}, yamlLines(v, indent+1)...)
+	case []interface{}:
+		if len(v) == 0 {
+			return []string{pad + key + ": []"}
+		}
+		return append([]string{pad + key + ":"}, yamlLines(v, indent)...)
+	default:
+		return []string{pad + key + ": " + yamlScalar(v)}
+	}
+}
+
+// yamlAmbiguousKeyPattern matches a bare key that YAML would otherwise parse
+// as a non-string scalar: an integer (the "200" in a responses map), a
+// float, or a reserved boolean/null word.
+var yamlAmbiguousKeyPattern = regexp.MustCompile(`(?i)^(-?[0-9]+(\.[0-9]+)?|true|false|null|yes|no|on|off|~)$`)
+
+// yamlKey quotes key only when leaving it bare would change its parsed
+// type, e.g. a responses map's "200" status code, so the common case
+// (path, method, and schema names) stays as plain, unquoted YAML.
+func yamlKey(key string) string {
+	if key == "" || yamlAmbiguousKeyPattern.MatchString(key) {
+		return strconv.Quote(key)
+	}
+	return key
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func yamlScalar(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return strconv.Quote(t)
+	case bool:
+		return strconv.FormatBool(t)
+	case float64:
+		if t == float64(int64(t)) {
+			return strconv.FormatInt(int64(t), 10)
+		}
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
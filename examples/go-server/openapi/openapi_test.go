@@ -0,0 +1,83 @@
+package openapi
+
+import "testing"
+
+// TestBuildAndYAMLEndToEnd locks down Build + YAML output for an endpoint
+// with a path param and a response shape, including that the "200" response
+// key is quoted (it would otherwise parse as a YAML integer) while ordinary
+// keys like "openapi" and "get" are left bare.
+func TestBuildAndYAMLEndToEnd(t *testing.T) {
+	endpoints := []Endpoint{
+		{
+			Method:      "GET",
+			Path:        "/users/{id}",
+			Description: "Get a user by id",
+			DataShapes: []Shape{
+				{Name: "User", Shape: `{"id":1,"name":"Ada"}`, IsRequest: false},
+			},
+		},
+	}
+
+	doc := Build("Sample API", "1.0.0", endpoints)
+
+	item, ok := doc.Paths["/users/{id}"]
+	if !ok {
+		t.Fatalf("got paths %v, want /users/{id} present", doc.Paths)
+	}
+	op, ok := item["get"]
+	if !ok {
+		t.Fatalf("got methods %v, want get present", item)
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "id" {
+		t.Fatalf("got parameters %v, want one path param named id", op.Parameters)
+	}
+	if _, ok := op.Responses["200"]; !ok {
+		t.Fatalf("got responses %v, want 200 present", op.Responses)
+	}
+	if _, ok := doc.Components.Schemas["User"]; !ok {
+		t.Fatalf("got schemas %v, want User present", doc.Components.Schemas)
+	}
+
+	out, err := doc.YAML()
+	if err != nil {
+		t.Fatalf("YAML: %v", err)
+	}
+
+	if !containsLine(out, `"200":`) {
+		t.Errorf("got YAML %s, want the \"200\" response key quoted", out)
+	}
+	if containsLine(out, `"openapi":`) || containsLine(out, `"get":`) {
+		t.Errorf("got YAML %s, want ordinary keys like openapi and get left unquoted", out)
+	}
+}
+
+func containsLine(s, substr string) bool {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+	return false
+}
+
+// TestYAMLKeyQuotesOnlyAmbiguousKeys exercises yamlKey directly: numeric and
+// reserved-word keys must be quoted, everything else stays bare.
+func TestYAMLKeyQuotesOnlyAmbiguousKeys(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"200", `"200"`},
+		{"404", `"404"`},
+		{"true", `"true"`},
+		{"null", `"null"`},
+		{"openapi", "openapi"},
+		{"get", "get"},
+		{"User", "User"},
+	}
+	for _, tt := range tests {
+		if got := yamlKey(tt.key); got != tt.want {
+			t.Errorf("yamlKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
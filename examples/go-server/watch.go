@@ -0,0 +1,348 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchEvent is a single change to the set of discovered endpoints, pushed
+// to every subscriber of GET /stream.
+type WatchEvent struct {
+	Type     string           `json:"type"` // endpoint-added | endpoint-removed | endpoint-changed
+	Endpoint APIDocumentation `json:"endpoint"`
+}
+
+// Watcher keeps a live, per-file endpoint index for a directory, re-parsing
+// it on every change to a .go file and diffing the result against the previous
+// snapshot to publish WatchEvents to any GET /stream subscribers.
+type Watcher struct {
+	dir string
+
+	mu     sync.Mutex
+	byFile map[string][]APIDocumentation
+
+	subMu       sync.Mutex
+	subscribers map[chan WatchEvent]struct{}
+
+	fsWatcher *fsnotify.Watcher
+}
+
+// NewWatcher creates a Watcher for dir, performs its initial parse, and
+// starts the background goroutine that reacts to filesystem changes.
+func NewWatcher(dir string) (*Watcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		dir:         dir,
+		subscribers: make(map[chan WatchEvent]struct{}),
+		fsWatcher:   fsWatcher,
+	}
+
+	if err := w.watchSubdirs(dir); err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+
+	byFile, err := w.reparse()
+	if err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+	w.byFile = byFile
+
+	go w.run()
+	return w, nil
+}
+
+// watchSubdirs registers dir and every subdirectory with fsnotify, since a
+// single fsnotify watch is not recursive.
+func (w *Watcher) watchSubdirs(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return w.fsWatcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// run reacts to fsnotify events on .go files by re-parsing the directory
+// and diffing the result against the previous snapshot. Re-parsing the
+// whole package rather than just the changed file keeps the package's type
+// information consistent; the byFile index is what makes the diff cheap
+// and precise instead of re-announcing every endpoint on every change.
+func (w *Watcher) run() {
+	for {
+		select {
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if !strings.HasSuffix(event.Name, ".go") {
+				continue
+			}
+			if err := w.reparseAndDiff(); err != nil {
+				log.Printf("watch %s: reparse error: %v", w.dir, err)
+			}
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("watch %s: fsnotify error: %v", w.dir, err)
+		}
+	}
+}
+
+func (w *Watcher) reparse() (map[string][]APIDocumentation, error) {
+	analyzer := NewAnalyzer()
+	if err := analyzer.ParseDirectory(w.dir); err != nil {
+		return nil, err
+	}
+	return analyzer.ByFile(), nil
+}
+
+func (w *Watcher) reparseAndDiff() error {
+	next, err := w.reparse()
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	prev := w.byFile
+	w.byFile = next
+	w.mu.Unlock()
+
+	for _, event := range diffByFile(prev, next) {
+		w.publish(event)
+	}
+	return nil
+}
+
+// Snapshot returns every currently known endpoint across all files.
+func (w *Watcher) Snapshot() []APIDocumentation {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var all []APIDocumentation
+	for _, docs := range w.byFile {
+		all = append(all, docs...)
+	}
+	return all
+}
+
+// Subscribe registers a channel to receive future WatchEvents. Call the
+// returned unsubscribe function when the subscriber is done listening.
+func (w *Watcher) Subscribe() (<-chan WatchEvent, func()) {
+	ch := make(chan WatchEvent, 16)
+
+	w.subMu.Lock()
+	w.subscribers[ch] = struct{}{}
+	w.subMu.Unlock()
+
+	unsubscribe := func() {
+		w.subMu.Lock()
+		delete(w.subscribers, ch)
+		w.subMu.Unlock()
+		close(ch)
+	}
+	return ch, unsubscribe
+}
+
+func (w *Watcher) publish(event WatchEvent) {
+	w.subMu.Lock()
+	defer w.subMu.Unlock()
+	for ch := range w.subscribers {
+		select {
+		case ch <- event:
+		default: // a slow subscriber misses an event rather than blocking the watcher
+		}
+	}
+}
+
+// Close stops the underlying fsnotify watcher.
+func (w *Watcher) Close() error {
+	return w.fsWatcher.Close()
+}
+
+// endpointKey identifies an endpoint for diffing purposes.
+func endpointKey(doc APIDocumentation) string {
+	return doc.Method + " " + doc.Path
+}
+
+// diffByFile compares the previous and next per-file endpoint snapshots and
+// returns the WatchEvents needed to bring a subscriber from prev to next.
+func diffByFile(prev, next map[string][]APIDocumentation) []WatchEvent {
+	var events []WatchEvent
+
+	for file, nextDocs := range next {
+		prevByKey := indexByKey(prev[file])
+		nextByKey := indexByKey(nextDocs)
+
+		for key, doc := range nextByKey {
+			prevDoc, existed := prevByKey[key]
+			switch {
+			case !existed:
+				events = append(events, WatchEvent{Type: "endpoint-added", Endpoint: doc})
+			case !sameEndpoint(prevDoc, doc):
+				events = append(events, WatchEvent{Type: "endpoint-changed", Endpoint: doc})
+			}
+		}
+		for key, doc := range prevByKey {
+			if _, stillPresent := nextByKey[key]; !stillPresent {
+				events = append(events, WatchEvent{Type: "endpoint-removed", Endpoint: doc})
+			}
+		}
+	}
+
+	for file, prevDocs := range prev {
+		if _, stillTracked := next[file]; stillTracked {
+			continue
+		}
+		for _, doc := range prevDocs {
+			events = append(events, WatchEvent{Type: "endpoint-removed", Endpoint: doc})
+		}
+	}
+
+	return events
+}
+
+func indexByKey(docs []APIDocumentation) map[string]APIDocumentation {
+	index := make(map[string]APIDocumentation, len(docs))
+	for _, doc := range docs {
+		index[endpointKey(doc)] = doc
+	}
+	return index
+}
+
+func sameEndpoint(a, b APIDocumentation) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(aJSON) == string(bJSON)
+}
+
+var (
+	watchersMu sync.Mutex
+	watchers   = make(map[string]*Watcher)
+)
+
+// registerWatcher returns the Watcher for dir, creating and starting one if
+// this is the first time dir has been watched.
+func registerWatcher(dir string) (*Watcher, error) {
+	watchersMu.Lock()
+	defer watchersMu.Unlock()
+
+	if w, ok := watchers[dir]; ok {
+		return w, nil
+	}
+
+	w, err := NewWatcher(dir)
+	if err != nil {
+		return nil, err
+	}
+	watchers[dir] = w
+	return w, nil
+}
+
+func lookupWatcher(dir string) (*Watcher, bool) {
+	watchersMu.Lock()
+	defer watchersMu.Unlock()
+	w, ok := watchers[dir]
+	return w, ok
+}
+
+// registerWatchHandlers wires POST /watch and GET /stream onto the default
+// ServeMux, turning the analyzer from a one-shot batch tool into a
+// long-running IDE/CI companion.
+func registerWatchHandlers() {
+	http.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		dir := r.URL.Query().Get("dir")
+		if dir == "" {
+			dir = "."
+		}
+
+		if _, err := registerWatcher(dir); err != nil {
+			http.Error(w, fmt.Sprintf("Error watching directory: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "watching", "dir": dir})
+	})
+
+	http.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+		dir := r.URL.Query().Get("dir")
+		if dir == "" {
+			dir = "."
+		}
+
+		watcher, ok := lookupWatcher(dir)
+		if !ok {
+			http.Error(w, fmt.Sprintf("Directory %q is not being watched; POST /watch?dir=%s first", dir, dir), http.StatusNotFound)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		// Subscribe before snapshotting, so a change landing in the gap
+		// between the two is seen as a live event rather than silently
+		// missed; the subscriber may see it twice (once in the snapshot,
+		// once as a live endpoint-added), which is harmless.
+		events, unsubscribe := watcher.Subscribe()
+		defer unsubscribe()
+
+		for _, doc := range watcher.Snapshot() {
+			payload, err := json.Marshal(doc)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: endpoint-added\ndata: %s\n\n", payload)
+		}
+		flusher.Flush()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				payload, err := json.Marshal(event.Endpoint)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+				flusher.Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package main
+
+import "testing"
+
+func doc(method, path, description string) APIDocumentation {
+	return APIDocumentation{Method: method, Path: path, Description: description}
+}
+
+func eventSet(events []WatchEvent) map[string]string {
+	set := make(map[string]string, len(events))
+	for _, e := range events {
+		set[e.Type+" "+endpointKey(e.Endpoint)] = e.Endpoint.Description
+	}
+	return set
+}
+
+func TestDiffByFileDetectsAdded(t *testing.T) {
+	prev := map[string][]APIDocumentation{
+		"a.go": {doc("GET", "/users", "list users")},
+	}
+	next := map[string][]APIDocumentation{
+		"a.go": {doc("GET", "/users", "list users"), doc("POST", "/users", "create user")},
+	}
+
+	events := diffByFile(prev, next)
+	got := eventSet(events)
+	want := map[string]string{"endpoint-added POST /users": "create user"}
+	if len(got) != len(want) {
+		t.Fatalf("got events %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("got %v, want %q for %q", got, v, k)
+		}
+	}
+}
+
+func TestDiffByFileDetectsChanged(t *testing.T) {
+	prev := map[string][]APIDocumentation{
+		"a.go": {doc("GET", "/users", "list users")},
+	}
+	next := map[string][]APIDocumentation{
+		"a.go": {doc("GET", "/users", "list all users")},
+	}
+
+	events := diffByFile(prev, next)
+	if len(events) != 1 || events[0].Type != "endpoint-changed" || events[0].Endpoint.Description != "list all users" {
+		t.Fatalf("got %+v, want one endpoint-changed event with the new description", events)
+	}
+}
+
+func TestDiffByFileDetectsRemovedWithinFile(t *testing.T) {
+	prev := map[string][]APIDocumentation{
+		"a.go": {doc("GET", "/users", "list users"), doc("DELETE", "/users/{id}", "delete user")},
+	}
+	next := map[string][]APIDocumentation{
+		"a.go": {doc("GET", "/users", "list users")},
+	}
+
+	events := diffByFile(prev, next)
+	if len(events) != 1 || events[0].Type != "endpoint-removed" || events[0].Endpoint.Path != "/users/{id}" {
+		t.Fatalf("got %+v, want one endpoint-removed event for /users/{id}", events)
+	}
+}
+
+func TestDiffByFileDetectsRemovedWholeFile(t *testing.T) {
+	prev := map[string][]APIDocumentation{
+		"a.go": {doc("GET", "/users", "list users")},
+		"b.go": {doc("GET", "/health", "health check")},
+	}
+	next := map[string][]APIDocumentation{
+		"a.go": {doc("GET", "/users", "list users")},
+	}
+
+	events := diffByFile(prev, next)
+	if len(events) != 1 || events[0].Type != "endpoint-removed" || events[0].Endpoint.Path != "/health" {
+		t.Fatalf("got %+v, want one endpoint-removed event for the deleted file's /health endpoint", events)
+	}
+}
+
+func TestDiffByFileNoChangesProducesNoEvents(t *testing.T) {
+	snapshot := map[string][]APIDocumentation{
+		"a.go": {doc("GET", "/users", "list users")},
+	}
+
+	if events := diffByFile(snapshot, snapshot); len(events) != 0 {
+		t.Fatalf("got %v, want no events for an unchanged snapshot", events)
+	}
+}
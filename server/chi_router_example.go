@@ -0,0 +1,74 @@
+// Sample file showing Chi router patterns for the documentation generator
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func chiMain() {
+	r := chi.NewRouter()
+
+	r.Get("/users", getChiUsers)
+	r.Post("/users", createChiUser)
+
+	r.With(loggingMiddleware, authMiddleware).Get("/admin", adminDashboard)
+	r.Method("GET", "/ping", http.HandlerFunc(pingHandler))
+
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Get("/health", chiHealthCheck)
+
+		r.Route("/users", func(r chi.Router) {
+			r.Get("/{id}", getChiUserByID)
+		})
+	})
+
+	r.Mount("/debug", chiDebugRouter())
+
+	http.ListenAndServe(":8081", r)
+}
+
+func chiDebugRouter() http.Handler {
+	return chi.NewRouter()
+}
+
+func loggingMiddleware(next http.Handler) http.Handler {
+	return next
+}
+
+func authMiddleware(next http.Handler) http.Handler {
+	return next
+}
+
+func getChiUsers(w http.ResponseWriter, r *http.Request) {
+	// GET /users - List all users
+	w.Write([]byte("Chi users list"))
+}
+
+func createChiUser(w http.ResponseWriter, r *http.Request) {
+	// POST /users - Create a new user
+	w.Write([]byte("Chi user created"))
+}
+
+func adminDashboard(w http.ResponseWriter, r *http.Request) {
+	// GET /admin - Admin dashboard, behind logging/auth middleware
+	w.Write([]byte("Admin dashboard"))
+}
+
+func pingHandler(w http.ResponseWriter, r *http.Request) {
+	// GET /ping - Liveness probe
+	w.Write([]byte("pong"))
+}
+
+func chiHealthCheck(w http.ResponseWriter, r *http.Request) {
+	// GET /api/v1/health - Health check endpoint
+	w.Write([]byte("OK"))
+}
+
+func getChiUserByID(w http.ResponseWriter, r *http.Request) {
+	// GET /api/v1/users/{id} - Get a specific user
+	id := chi.URLParam(r, "id")
+	w.Write([]byte("Chi user " + id))
+}
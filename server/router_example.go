@@ -26,6 +26,7 @@ func setupRoutes() {
 	http.HandleFunc("/api/v1/users", getUsersHandler)
 	http.HandleFunc("/api/v1/scan", scanHandler)
 	http.HandleFunc("/api/v1/health", healthHandler)
+	http.HandleFunc("/echo-map", echoMapHandler)
 }
 
 // Simulate Gin-like router calls for pattern matching
@@ -73,6 +73,17 @@ func healthHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+func echoMapHandler(w http.ResponseWriter, r *http.Request) {
+	// Handle POST /echo-map
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"echoed": req["message"]})
+}
+
 func scanHandler(w http.ResponseWriter, r *http.Request) {
 	// Handle POST /scan - upload and scan files
 	response := map[string]string{"message": "File scanned successfully"}